@@ -0,0 +1,11 @@
+package auth
+
+import goshopify "github.com/tax-automate/go-shopify"
+
+// NewClient builds a goshopify.Client authenticated with an access token
+// obtained from ExchangeCode, for multi-tenant apps that look up each
+// shop's stored token per-request rather than holding a single static
+// token.
+func NewClient(app goshopify.App, shop string, token *AccessToken, opts ...goshopify.Option) *goshopify.Client {
+	return goshopify.NewClient(app, shop, token.AccessToken, opts...)
+}
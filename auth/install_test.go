@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"testing"
+)
+
+func signedQuery(secret string, params map[string]string) url.Values {
+	q := url.Values{}
+	for k, v := range params {
+		q.Set(k, v)
+	}
+
+	message := ""
+	for i, k := range sortedKeys(params) {
+		if i > 0 {
+			message += "&"
+		}
+		message += k + "=" + params[k]
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	q.Set("hmac", hex.EncodeToString(mac.Sum(nil)))
+	return q
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+	return keys
+}
+
+func TestVerifyInstallRequestSuccess(t *testing.T) {
+	q := signedQuery("shhh", map[string]string{
+		"shop":      "my-shop.myshopify.com",
+		"timestamp": "1234567890",
+	})
+
+	if err := VerifyInstallRequest(q, "shhh"); err != nil {
+		t.Fatalf("VerifyInstallRequest() = %v, want nil", err)
+	}
+}
+
+func TestVerifyInstallRequestWrongSecret(t *testing.T) {
+	q := signedQuery("shhh", map[string]string{
+		"shop":      "my-shop.myshopify.com",
+		"timestamp": "1234567890",
+	})
+
+	if err := VerifyInstallRequest(q, "wrong"); err == nil {
+		t.Fatal("VerifyInstallRequest() = nil, want error")
+	}
+}
+
+func TestVerifyInstallRequestTamperedParam(t *testing.T) {
+	q := signedQuery("shhh", map[string]string{
+		"shop":      "my-shop.myshopify.com",
+		"timestamp": "1234567890",
+	})
+	q.Set("shop", "attacker-shop.myshopify.com")
+
+	if err := VerifyInstallRequest(q, "shhh"); err == nil {
+		t.Fatal("VerifyInstallRequest() = nil, want error")
+	}
+}
+
+func TestVerifyInstallRequestMissingHMAC(t *testing.T) {
+	q := url.Values{"shop": {"my-shop.myshopify.com"}}
+
+	if err := VerifyInstallRequest(q, "shhh"); err == nil {
+		t.Fatal("VerifyInstallRequest() = nil, want error")
+	}
+}
+
+func TestShopOrigin(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "my-shop", want: "my-shop.myshopify.com"},
+		{in: "my-shop.myshopify.com", want: "my-shop.myshopify.com"},
+		{in: "https://my-shop.myshopify.com/", want: "my-shop.myshopify.com"},
+		{in: "my-shop.example.com", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := ShopOrigin(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ShopOrigin(%q) = nil error, want error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ShopOrigin(%q) = %v, want nil error", tc.in, err)
+			continue
+		}
+		if got.Host != tc.want {
+			t.Errorf("ShopOrigin(%q).Host = %q, want %q", tc.in, got.Host, tc.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SessionTokenClaims are the claims Shopify signs into the session token it
+// gives an embedded app's frontend, per the session token spec.
+// See: https://shopify.dev/docs/apps/auth/session-tokens
+type SessionTokenClaims struct {
+	Issuer      string `json:"iss"`
+	Destination string `json:"dest"`
+	Audience    string `json:"aud"`
+	Subject     string `json:"sub"`
+	ExpiresAt   int64  `json:"exp"`
+	NotBefore   int64  `json:"nbf"`
+	IssuedAt    int64  `json:"iat"`
+	JTI         string `json:"jti"`
+	SessionID   string `json:"sid"`
+}
+
+// VerifySessionToken validates a session token JWT handed to the backend by
+// an embedded app's frontend: its HS256 signature against apiSecret, and
+// that aud matches clientID, dest is a myshopify.com domain matching iss,
+// and the current time falls within [nbf, exp) (a zero nbf or exp is not
+// checked). It returns the decoded claims on success.
+func VerifySessionToken(token, clientID, apiSecret string) (*SessionTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("auth: malformed session token")
+	}
+	headerB64, claimsB64, sigB64 := parts[0], parts[1], parts[2]
+
+	sig, err := base64.RawURLEncoding.DecodeString(sigB64)
+	if err != nil {
+		return nil, fmt.Errorf("auth: malformed session token signature: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(apiSecret))
+	mac.Write([]byte(headerB64 + "." + claimsB64))
+	expected := mac.Sum(nil)
+	if !hmac.Equal(sig, expected) {
+		return nil, errors.New("auth: session token signature verification failed")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(claimsB64)
+	if err != nil {
+		return nil, fmt.Errorf("auth: malformed session token claims: %w", err)
+	}
+	var claims SessionTokenClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("auth: invalid session token claims: %w", err)
+	}
+
+	if claims.Audience != clientID {
+		return nil, fmt.Errorf("auth: session token aud %q does not match client id", claims.Audience)
+	}
+
+	destShop, err := ShopOrigin(claims.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("auth: session token dest: %w", err)
+	}
+	issShop, err := ShopOrigin(strings.TrimSuffix(claims.Issuer, "/admin"))
+	if err != nil {
+		return nil, fmt.Errorf("auth: session token iss: %w", err)
+	}
+	if destShop.Host != issShop.Host {
+		return nil, errors.New("auth: session token dest does not match iss")
+	}
+
+	now := time.Now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return nil, errors.New("auth: session token expired")
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, errors.New("auth: session token not yet valid")
+	}
+
+	return &claims, nil
+}
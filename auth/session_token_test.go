@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func signSessionToken(secret string, claims SessionTokenClaims) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		panic(err)
+	}
+	body := base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + body))
+	sig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return header + "." + body + "." + sig
+}
+
+func validSessionTokenClaims() SessionTokenClaims {
+	now := time.Now().Unix()
+	return SessionTokenClaims{
+		Issuer:      "https://my-shop.myshopify.com/admin",
+		Destination: "https://my-shop.myshopify.com",
+		Audience:    "my-client-id",
+		Subject:     "1",
+		ExpiresAt:   now + 60,
+		NotBefore:   now - 60,
+		IssuedAt:    now - 60,
+	}
+}
+
+func TestVerifySessionTokenSuccess(t *testing.T) {
+	claims := validSessionTokenClaims()
+	token := signSessionToken("shhh", claims)
+
+	got, err := VerifySessionToken(token, "my-client-id", "shhh")
+	if err != nil {
+		t.Fatalf("VerifySessionToken() = %v, want nil", err)
+	}
+	if got.Audience != claims.Audience {
+		t.Errorf("Audience = %q, want %q", got.Audience, claims.Audience)
+	}
+}
+
+func TestVerifySessionTokenWrongSignature(t *testing.T) {
+	token := signSessionToken("shhh", validSessionTokenClaims())
+
+	if _, err := VerifySessionToken(token, "my-client-id", "different-secret"); err == nil {
+		t.Fatal("VerifySessionToken() = nil error, want error")
+	}
+}
+
+func TestVerifySessionTokenAudienceMismatch(t *testing.T) {
+	token := signSessionToken("shhh", validSessionTokenClaims())
+
+	if _, err := VerifySessionToken(token, "other-client-id", "shhh"); err == nil {
+		t.Fatal("VerifySessionToken() = nil error, want error")
+	}
+}
+
+func TestVerifySessionTokenDestIssMismatch(t *testing.T) {
+	claims := validSessionTokenClaims()
+	claims.Destination = "https://attacker-shop.myshopify.com"
+	token := signSessionToken("shhh", claims)
+
+	if _, err := VerifySessionToken(token, "my-client-id", "shhh"); err == nil {
+		t.Fatal("VerifySessionToken() = nil error, want error")
+	}
+}
+
+func TestVerifySessionTokenExpired(t *testing.T) {
+	claims := validSessionTokenClaims()
+	claims.ExpiresAt = time.Now().Unix() - 60
+	token := signSessionToken("shhh", claims)
+
+	if _, err := VerifySessionToken(token, "my-client-id", "shhh"); err == nil {
+		t.Fatal("VerifySessionToken() = nil error, want error")
+	}
+}
+
+func TestVerifySessionTokenNotYetValid(t *testing.T) {
+	claims := validSessionTokenClaims()
+	claims.NotBefore = time.Now().Unix() + 60
+	token := signSessionToken("shhh", claims)
+
+	if _, err := VerifySessionToken(token, "my-client-id", "shhh"); err == nil {
+		t.Fatal("VerifySessionToken() = nil error, want error")
+	}
+}
@@ -0,0 +1,564 @@
+package goshopify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BulkOperationStatus is the status of a Shopify bulk operation as reported
+// by currentBulkOperation.status.
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusCreated   BulkOperationStatus = "CREATED"
+	BulkOperationStatusRunning   BulkOperationStatus = "RUNNING"
+	BulkOperationStatusCompleted BulkOperationStatus = "COMPLETED"
+	BulkOperationStatusCanceling BulkOperationStatus = "CANCELING"
+	BulkOperationStatusCanceled  BulkOperationStatus = "CANCELED"
+	BulkOperationStatusFailed    BulkOperationStatus = "FAILED"
+	BulkOperationStatusExpired   BulkOperationStatus = "EXPIRED"
+)
+
+// BulkOperation mirrors Shopify's BulkOperation GraphQL object.
+type BulkOperation struct {
+	ID             string              `json:"id"`
+	Status         BulkOperationStatus `json:"status"`
+	ErrorCode      string              `json:"errorCode,omitempty"`
+	ObjectCount    string              `json:"objectCount"`
+	URL            string              `json:"url,omitempty"`
+	PartialDataURL string              `json:"partialDataUrl,omitempty"`
+}
+
+// Done reports whether the operation has reached a terminal state.
+func (b BulkOperation) Done() bool {
+	switch b.Status {
+	case BulkOperationStatusCompleted, BulkOperationStatusCanceled, BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// BulkOperationsService wraps Shopify's bulkOperationRunQuery flow: submit a
+// query, poll currentBulkOperation until it is done, and stream-decode the
+// resulting JSONL. It exists because paginating REST endpoints like
+// ProductServiceOp.ListWithPagination is impractical for stores with
+// hundreds of thousands of records.
+// See: https://shopify.dev/docs/api/usage/bulk-operations/queries
+type BulkOperationsService interface {
+	RunQuery(ctx context.Context, query string) (*BulkOperation, error)
+	Current(ctx context.Context) (*BulkOperation, error)
+	Wait(ctx context.Context, pollInterval time.Duration) (*BulkOperation, error)
+	Cancel(ctx context.Context, id string) error
+}
+
+// BulkOperationsServiceOp handles communication with the bulk operations
+// GraphQL flow.
+type BulkOperationsServiceOp struct {
+	client *Client
+}
+
+// BulkOperations returns the service used to run and poll bulk operations.
+func (c *Client) BulkOperations() BulkOperationsService {
+	return &BulkOperationsServiceOp{client: c}
+}
+
+const runQueryMutation = `
+mutation bulkOperationRunQuery($query: String!) {
+	bulkOperationRunQuery(query: $query) {
+		bulkOperation { id status }
+		userErrors { field message }
+	}
+}`
+
+const currentBulkOperationQuery = `
+{
+	currentBulkOperation {
+		id
+		status
+		errorCode
+		objectCount
+		url
+		partialDataUrl
+	}
+}`
+
+const cancelBulkOperationMutation = `
+mutation bulkOperationCancel($id: ID!) {
+	bulkOperationCancel(id: $id) {
+		bulkOperation { id status }
+		userErrors { field message }
+	}
+}`
+
+// RunQuery submits query as a bulkOperationRunQuery and returns the bulk
+// operation in its initial CREATED state. Call Wait (or poll Current
+// yourself) to find out when it finishes.
+func (s *BulkOperationsServiceOp) RunQuery(ctx context.Context, query string) (*BulkOperation, error) {
+	var resp struct {
+		BulkOperationRunQuery struct {
+			BulkOperation *BulkOperation     `json:"bulkOperation"`
+			UserErrors    []GraphQLUserError `json:"userErrors"`
+		} `json:"bulkOperationRunQuery"`
+	}
+
+	vars := map[string]interface{}{"query": query}
+	if err := s.client.GraphQL().Mutate(ctx, runQueryMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.BulkOperationRunQuery.UserErrors) > 0 {
+		return nil, fmt.Errorf("bulkOperationRunQuery: %s", resp.BulkOperationRunQuery.UserErrors[0].Message)
+	}
+	return resp.BulkOperationRunQuery.BulkOperation, nil
+}
+
+// Current returns the shop's currently running (or most recently finished)
+// bulk operation.
+func (s *BulkOperationsServiceOp) Current(ctx context.Context) (*BulkOperation, error) {
+	var resp struct {
+		CurrentBulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}
+	if err := s.client.GraphQL().Query(ctx, currentBulkOperationQuery, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.CurrentBulkOperation, nil
+}
+
+// Cancel cancels a running bulk operation.
+func (s *BulkOperationsServiceOp) Cancel(ctx context.Context, id string) error {
+	var resp struct {
+		BulkOperationCancel struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		} `json:"bulkOperationCancel"`
+	}
+	vars := map[string]interface{}{"id": id}
+	if err := s.client.GraphQL().Mutate(ctx, cancelBulkOperationMutation, vars, &resp); err != nil {
+		return err
+	}
+	if len(resp.BulkOperationCancel.UserErrors) > 0 {
+		return fmt.Errorf("bulkOperationCancel: %s", resp.BulkOperationCancel.UserErrors[0].Message)
+	}
+	return nil
+}
+
+// Wait polls Current every pollInterval until the shop's bulk operation
+// reaches a terminal state, then returns it.
+func (s *BulkOperationsServiceOp) Wait(ctx context.Context, pollInterval time.Duration) (*BulkOperation, error) {
+	for {
+		op, err := s.Current(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if op == nil || op.Done() {
+			return op, nil
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// GraphQLUserError mirrors the `userErrors` array Shopify returns on
+// mutations, as opposed to the top-level `errors` array.
+type GraphQLUserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+}
+
+// bulkMoney is the MoneyV2 shape GraphQL returns for amount/fee/net fields,
+// as opposed to the bare decimal strings REST uses.
+type bulkMoney struct {
+	Amount       string `json:"amount"`
+	CurrencyCode string `json:"currencyCode"`
+}
+
+func (m bulkMoney) decimal() (decimal.Decimal, error) {
+	if m.Amount == "" {
+		return decimal.Decimal{}, nil
+	}
+	return decimal.NewFromString(m.Amount)
+}
+
+// gidNumericID extracts the trailing numeric ID from a GraphQL gid, e.g.
+// "gid://shopify/Product/123" -> 123, to populate the REST structs' int64
+// ID fields.
+func gidNumericID(gid string) (int64, error) {
+	idx := strings.LastIndex(gid, "/")
+	if idx < 0 || idx == len(gid)-1 {
+		return 0, fmt.Errorf("malformed gid %q", gid)
+	}
+	return strconv.ParseInt(gid[idx+1:], 10, 64)
+}
+
+// bulkProductNode is the shape of a product row in the JSONL produced by
+// bulkProductsQuery: GraphQL ids and fields, not the flat REST shape.
+type bulkProductNode struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Handle      string `json:"handle"`
+	Vendor      string `json:"vendor"`
+	ProductType string `json:"productType"`
+	Status      string `json:"status"`
+}
+
+func (n bulkProductNode) toProduct() (Product, error) {
+	id, err := gidNumericID(n.ID)
+	if err != nil {
+		return Product{}, err
+	}
+	return Product{
+		ID:          id,
+		Title:       n.Title,
+		Handle:      n.Handle,
+		Vendor:      n.Vendor,
+		ProductType: n.ProductType,
+		Status:      n.Status,
+	}, nil
+}
+
+// bulkPayoutNode is the shape of a payout row in the JSONL produced by
+// bulkPayoutsQuery.
+type bulkPayoutNode struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Currency string `json:"currencyCode"`
+}
+
+func (n bulkPayoutNode) toPayout() (Payout, error) {
+	id, err := gidNumericID(n.ID)
+	if err != nil {
+		return Payout{}, err
+	}
+	return Payout{
+		Id:       id,
+		Currency: n.Currency,
+		Status:   PayoutStatus(n.Status),
+	}, nil
+}
+
+// bulkPayoutTransactionNode is the shape of a transaction row in the JSONL
+// produced by bulkPayoutTransactionsQuery: amount/fee/net arrive as MoneyV2
+// objects, not bare decimal strings.
+type bulkPayoutTransactionNode struct {
+	ID     string    `json:"id"`
+	Type   string    `json:"type"`
+	Amount bulkMoney `json:"amount"`
+	Fee    bulkMoney `json:"fee"`
+	Net    bulkMoney `json:"net"`
+}
+
+func (n bulkPayoutTransactionNode) toPayoutTransaction() (PayoutTransaction, error) {
+	id, err := gidNumericID(n.ID)
+	if err != nil {
+		return PayoutTransaction{}, err
+	}
+	amount, err := n.Amount.decimal()
+	if err != nil {
+		return PayoutTransaction{}, fmt.Errorf("amount: %w", err)
+	}
+	fee, err := n.Fee.decimal()
+	if err != nil {
+		return PayoutTransaction{}, fmt.Errorf("fee: %w", err)
+	}
+	net, err := n.Net.decimal()
+	if err != nil {
+		return PayoutTransaction{}, fmt.Errorf("net: %w", err)
+	}
+	return PayoutTransaction{
+		ID:       id,
+		Type:     n.Type,
+		Currency: n.Amount.CurrencyCode,
+		Amount:   amount,
+		Fee:      fee,
+		Net:      net,
+	}, nil
+}
+
+// downloadJSONL fetches the bulk operation's result URL and decodes it one
+// JSON object per line, invoking decode for each line. The result URL is a
+// pre-signed, time-limited link to Google Cloud Storage; it takes no auth
+// headers of its own.
+func downloadJSONL(ctx context.Context, httpClient *http.Client, url string, decode func(line []byte) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bulk operation result download failed: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := decode(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ListBulk runs a bulkOperationRunQuery for products, waits for it to
+// complete, and streams the decoded results on the returned channel. Only
+// options.Vendor is applied as a filter; the other fields of options are
+// ignored. It is intended for stores with large enough catalogs that
+// ProductServiceOp.ListWithPagination would take an impractical number of
+// requests. The channel is closed when the download finishes or ctx is
+// cancelled; errors are sent on errCh.
+func (s *ProductServiceOp) ListBulk(ctx context.Context, options ProductListOptions) (<-chan Product, <-chan error) {
+	out := make(chan Product)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		bulk := s.client.BulkOperations()
+		op, err := bulk.RunQuery(ctx, bulkProductsQuery(options))
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		op, err = bulk.Wait(ctx, 2*time.Second)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		if op.Status != BulkOperationStatusCompleted {
+			errCh <- fmt.Errorf("bulk operation ended in status %s (errorCode=%s)", op.Status, op.ErrorCode)
+			return
+		}
+		if op.URL == "" {
+			return
+		}
+
+		err = downloadJSONL(ctx, s.client.httpClient(), op.URL, func(line []byte) error {
+			var node bulkProductNode
+			if err := json.Unmarshal(line, &node); err != nil {
+				return err
+			}
+			p, err := node.toProduct()
+			if err != nil {
+				return err
+			}
+			select {
+			case out <- p:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return out, errCh
+}
+
+func bulkProductsQuery(options ProductListOptions) string {
+	filter := ""
+	if options.Vendor != "" {
+		filter = fmt.Sprintf(`(query: %s)`, bulkSearchQueryLiteral("vendor", options.Vendor))
+	}
+	return fmt.Sprintf(`
+{
+	products%s {
+		edges {
+			node {
+				id
+				title
+				handle
+				vendor
+				productType
+				status
+			}
+		}
+	}
+}`, filter)
+}
+
+// ListBulk runs a bulkOperationRunQuery for payouts, waits for it to
+// complete, and streams the decoded results on the returned channel. See
+// ProductServiceOp.ListBulk for the rationale and channel semantics.
+func (s *PayoutsServiceOp) ListBulk(ctx context.Context) (<-chan Payout, <-chan error) {
+	out := make(chan Payout)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		bulk := s.client.BulkOperations()
+		op, err := bulk.RunQuery(ctx, bulkPayoutsQuery)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		op, err = bulk.Wait(ctx, 2*time.Second)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		if op.Status != BulkOperationStatusCompleted {
+			errCh <- fmt.Errorf("bulk operation ended in status %s (errorCode=%s)", op.Status, op.ErrorCode)
+			return
+		}
+		if op.URL == "" {
+			return
+		}
+
+		err = downloadJSONL(ctx, s.client.httpClient(), op.URL, func(line []byte) error {
+			var node bulkPayoutNode
+			if err := json.Unmarshal(line, &node); err != nil {
+				return err
+			}
+			p, err := node.toPayout()
+			if err != nil {
+				return err
+			}
+			select {
+			case out <- p:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return out, errCh
+}
+
+const bulkPayoutsQuery = `
+{
+	shopifyPaymentsAccount {
+		payouts {
+			edges {
+				node {
+					id
+					issuedAt
+					status
+					currencyCode
+				}
+			}
+		}
+	}
+}`
+
+// TransactionsBulk runs a bulkOperationRunQuery for every transaction on the
+// given payout, waits for it to complete, and streams the decoded results
+// on the returned channel. See ProductServiceOp.ListBulk for the rationale
+// and channel semantics; this is the bulk counterpart to
+// TransactionsForPayout for payouts with more transactions than REST
+// pagination can reasonably page through.
+func (s *PayoutsServiceOp) TransactionsBulk(ctx context.Context, payoutID int64) (<-chan PayoutTransaction, <-chan error) {
+	out := make(chan PayoutTransaction)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		bulk := s.client.BulkOperations()
+		op, err := bulk.RunQuery(ctx, bulkPayoutTransactionsQuery(payoutID))
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		op, err = bulk.Wait(ctx, 2*time.Second)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		if op.Status != BulkOperationStatusCompleted {
+			errCh <- fmt.Errorf("bulk operation ended in status %s (errorCode=%s)", op.Status, op.ErrorCode)
+			return
+		}
+		if op.URL == "" {
+			return
+		}
+
+		err = downloadJSONL(ctx, s.client.httpClient(), op.URL, func(line []byte) error {
+			var node bulkPayoutTransactionNode
+			if err := json.Unmarshal(line, &node); err != nil {
+				return err
+			}
+			t, err := node.toPayoutTransaction()
+			if err != nil {
+				return err
+			}
+			select {
+			case out <- t:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return out, errCh
+}
+
+// bulkSearchQueryLiteral builds a GraphQL string literal for a
+// `field:value` search filter (e.g. in `products(query: ...)`), escaping
+// value so that quotes or backslashes in it (e.g. a vendor name like
+// O'Brien's) can't break out of the literal and produce an invalid
+// document.
+func bulkSearchQueryLiteral(field, value string) string {
+	return strconv.Quote(fmt.Sprintf("%s:%s", field, value))
+}
+
+func bulkPayoutTransactionsQuery(payoutID int64) string {
+	return fmt.Sprintf(`
+{
+	shopifyPaymentsAccount {
+		payouts(query: "id:%d") {
+			edges {
+				node {
+					transactions {
+						edges {
+							node {
+								id
+								type
+								amount { amount currencyCode }
+								fee { amount currencyCode }
+								net { amount currencyCode }
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}`, payoutID)
+}
@@ -0,0 +1,329 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+const (
+	defaultAPIVersion = "2023-01"
+	userAgent         = "goshopify"
+)
+
+// App holds the API credentials registered for a Shopify app. NewClient
+// accepts one but does not read any of its fields.
+type App struct {
+	ApiKey      string
+	ApiSecret   string
+	RedirectUrl string
+	Scope       string
+	Password    string
+}
+
+// Option configures a Client constructed by NewClient.
+type Option func(c *Client)
+
+// WithVersion pins the Admin API version Client talks to, overriding
+// defaultAPIVersion.
+func WithVersion(apiVersion string) Option {
+	return func(c *Client) {
+		c.apiVersion = apiVersion
+	}
+}
+
+// Client manages communication with the Shopify Admin REST and GraphQL
+// APIs for a single shop.
+type Client struct {
+	httpC       *http.Client
+	baseURL     *url.URL
+	token       string
+	apiVersion  string
+	rateLimiter RateLimiter
+}
+
+// NewClient returns a Client configured for shopName (either a bare handle
+// like "my-shop" or a full "my-shop.myshopify.com" domain), authenticated
+// with token (an offline or online access token, or an app password for
+// private apps).
+func NewClient(app App, shopName, token string, opts ...Option) *Client {
+	c := &Client{
+		httpC:       http.DefaultClient,
+		baseURL:     shopBaseURL(shopName),
+		token:       token,
+		apiVersion:  defaultAPIVersion,
+		rateLimiter: newLeakyBucketLimiter(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.baseURL.Path = "/admin/api/" + c.apiVersion + "/"
+	return c
+}
+
+func shopBaseURL(shopName string) *url.URL {
+	host := strings.TrimSuffix(strings.TrimPrefix(shopName, "https://"), "/")
+	if !strings.Contains(host, ".") {
+		host += ".myshopify.com"
+	}
+	return &url.URL{Scheme: "https", Host: host, Path: "/admin/api/" + defaultAPIVersion + "/"}
+}
+
+// httpClient returns the underlying *http.Client, for code (like the bulk
+// operations download) that needs to make a request outside the
+// NewRequest/Do pipeline, e.g. to a pre-signed URL that takes no Shopify
+// auth headers.
+func (c *Client) httpClient() *http.Client {
+	return c.httpC
+}
+
+// NewRequest builds an authenticated request against path (relative to the
+// shop's Admin API root) carrying body, with options encoded onto the query
+// string.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader, options interface{}) (*http.Request, error) {
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	u := c.baseURL.ResolveReference(rel)
+
+	if options != nil {
+		values, err := query.Values(options)
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = values.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Shopify-Access-Token", c.token)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// send paces req against the shop's leaky bucket, sends it, retrying on 429
+// with the Retry-After-driven backoff in ratelimit.go, and decodes the
+// response body into v (if non-nil). The response header is returned so
+// callers that need more than the body (e.g. pagination Link headers) can
+// inspect it.
+func (c *Client) send(req *http.Request, v interface{}) (http.Header, error) {
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if bodyBytes != nil {
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+
+		if err := c.rateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpC.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if used, max, ok := parseAPICallLimit(resp.Header); ok {
+			c.rateLimiter.Update(used, max)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < maxRetries {
+			delay := retryAfterDelay(resp.Header, attempt)
+			resp.Body.Close()
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(delay):
+			}
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			respBody, _ := io.ReadAll(resp.Body)
+			return resp.Header, fmt.Errorf("goshopify: %s: %s", resp.Status, respBody)
+		}
+
+		if v == nil {
+			return resp.Header, nil
+		}
+		return resp.Header, json.NewDecoder(resp.Body).Decode(v)
+	}
+}
+
+// Do sends req and decodes the response body into v (if non-nil).
+func (c *Client) Do(req *http.Request, v interface{}) error {
+	_, err := c.send(req, v)
+	return err
+}
+
+// Get performs a GET request against path and decodes the response into resource.
+//
+// Deprecated: use GetContext instead.
+func (c *Client) Get(path string, resource interface{}, options interface{}) error {
+	return c.GetContext(context.Background(), path, resource, options)
+}
+
+// GetContext performs a GET request against path and decodes the response into resource.
+func (c *Client) GetContext(ctx context.Context, path string, resource interface{}, options interface{}) error {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil, options)
+	if err != nil {
+		return err
+	}
+	return c.Do(req, resource)
+}
+
+// Post performs a POST request against path with data as the JSON body and
+// decodes the response into resource.
+//
+// Deprecated: use PostContext instead.
+func (c *Client) Post(path string, data interface{}, resource interface{}) error {
+	return c.PostContext(context.Background(), path, data, resource)
+}
+
+// PostContext performs a POST request against path with data as the JSON
+// body and decodes the response into resource.
+func (c *Client) PostContext(ctx context.Context, path string, data interface{}, resource interface{}) error {
+	return c.doWithBody(ctx, http.MethodPost, path, data, resource)
+}
+
+// Put performs a PUT request against path with data as the JSON body and
+// decodes the response into resource.
+//
+// Deprecated: use PutContext instead.
+func (c *Client) Put(path string, data interface{}, resource interface{}) error {
+	return c.PutContext(context.Background(), path, data, resource)
+}
+
+// PutContext performs a PUT request against path with data as the JSON body
+// and decodes the response into resource.
+func (c *Client) PutContext(ctx context.Context, path string, data interface{}, resource interface{}) error {
+	return c.doWithBody(ctx, http.MethodPut, path, data, resource)
+}
+
+func (c *Client) doWithBody(ctx context.Context, method, path string, data interface{}, resource interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	req, err := c.NewRequest(ctx, method, path, bytes.NewReader(body), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.Do(req, resource)
+}
+
+// Delete performs a DELETE request against path.
+//
+// Deprecated: use DeleteContext instead.
+func (c *Client) Delete(path string) error {
+	return c.DeleteContext(context.Background(), path)
+}
+
+// DeleteContext performs a DELETE request against path.
+func (c *Client) DeleteContext(ctx context.Context, path string) error {
+	req, err := c.NewRequest(ctx, http.MethodDelete, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	return c.Do(req, nil)
+}
+
+// Count performs a GET request against path and returns the "count" field
+// of the response.
+//
+// Deprecated: use CountContext instead.
+func (c *Client) Count(path string, options interface{}) (int, error) {
+	return c.CountContext(context.Background(), path, options)
+}
+
+// CountContext performs a GET request against path and returns the "count"
+// field of the response.
+func (c *Client) CountContext(ctx context.Context, path string, options interface{}) (int, error) {
+	resource := new(struct {
+		Count int `json:"count"`
+	})
+	err := c.GetContext(ctx, path, resource, options)
+	return resource.Count, err
+}
+
+// ListWithPagination performs a GET request against path, decodes the
+// response into resource, and parses the Link header into a Pagination.
+//
+// Deprecated: use ListWithPaginationContext instead.
+func (c *Client) ListWithPagination(path string, resource interface{}, options interface{}) (*Pagination, error) {
+	return c.ListWithPaginationContext(context.Background(), path, resource, options)
+}
+
+// ListWithPaginationContext performs a GET request against path, decodes
+// the response into resource, and parses the Link header into a Pagination.
+func (c *Client) ListWithPaginationContext(ctx context.Context, path string, resource interface{}, options interface{}) (*Pagination, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := c.send(req, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := new(Pagination)
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		match := linkRegex.FindStringSubmatch(link)
+		if len(match) != 3 {
+			continue
+		}
+		rawURL, rel := match[1], match[2]
+		linkOptions, err := linkOptionsFromURL(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		switch rel {
+		case "next":
+			pagination.NextPageOptions = linkOptions
+		case "previous":
+			pagination.PreviousPageOptions = linkOptions
+		}
+	}
+
+	return pagination, nil
+}
+
+func linkOptionsFromURL(rawURL string) (*ListOptions, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &ListOptions{PageInfo: u.Query().Get("page_info")}, nil
+}
+
+// ListOptions are the pagination-related fields common to every paginated
+// REST list endpoint.
+type ListOptions struct {
+	PageInfo string `url:"page_info,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+}
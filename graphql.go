@@ -0,0 +1,184 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const graphqlBasePath = "graphql.json"
+
+// GraphQLService is an interface for interfacing with the GraphQL Admin API
+// of the Shopify API. Unlike the REST services, callers supply the document
+// and variables directly and decode the `data` field themselves, since the
+// shape of a GraphQL response depends entirely on the query.
+// See: https://shopify.dev/docs/api/admin-graphql
+type GraphQLService interface {
+	Query(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error
+	Mutate(ctx context.Context, mutation string, variables map[string]interface{}, out interface{}) error
+	QueryAll(ctx context.Context, query string, variables map[string]interface{}, pager GraphQLPager) error
+}
+
+// GraphQLServiceOp handles communication with the GraphQL Admin API. It
+// shares the underlying *http.Client, auth headers, and rate-limit
+// accounting with the REST services on Client.
+type GraphQLServiceOp struct {
+	client *Client
+}
+
+// GraphQL returns the service used to talk to the GraphQL Admin API.
+func (c *Client) GraphQL() GraphQLService {
+	return &GraphQLServiceOp{client: c}
+}
+
+// GraphQLError represents a single entry in a GraphQL response's top-level
+// "errors" array.
+type GraphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLThrottleStatus mirrors the `extensions.cost.throttleStatus` object
+// Shopify returns on every Admin GraphQL response, letting callers pace bulk
+// queries adaptively instead of waiting for a 429.
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// GraphQLCost mirrors the `extensions.cost` object of a GraphQL response.
+type GraphQLCost struct {
+	RequestedQueryCost int                   `json:"requestedQueryCost"`
+	ActualQueryCost    int                   `json:"actualQueryCost"`
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLPageInfo mirrors a Relay-style `pageInfo` field. Callers extract it
+// from their own query's shape and return it from a GraphQLPager so QueryAll
+// knows whether to keep going.
+type GraphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQLPager is implemented by callers of QueryAll. Decode extracts the
+// page of results from the raw `data` payload of a single GraphQL response,
+// applying them however the caller sees fit (e.g. appending to a slice), and
+// returns the pageInfo for that page so QueryAll knows whether to continue.
+type GraphQLPager interface {
+	Decode(data json.RawMessage) (GraphQLPageInfo, error)
+}
+
+type graphqlRequestBody struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphqlResponseBody struct {
+	Data       json.RawMessage `json:"data"`
+	Errors     []GraphQLError  `json:"errors,omitempty"`
+	Extensions struct {
+		Cost GraphQLCost `json:"cost"`
+	} `json:"extensions"`
+}
+
+func (s *GraphQLServiceOp) do(ctx context.Context, query string, variables map[string]interface{}, out interface{}) (GraphQLThrottleStatus, error) {
+	reqBody := graphqlRequestBody{Query: query, Variables: variables}
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return GraphQLThrottleStatus{}, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, graphqlBasePath, bytes.NewReader(payload), nil)
+	if err != nil {
+		return GraphQLThrottleStatus{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var respBody graphqlResponseBody
+	if err := s.client.Do(req, &respBody); err != nil {
+		return GraphQLThrottleStatus{}, err
+	}
+
+	if len(respBody.Errors) > 0 {
+		return respBody.Extensions.Cost.ThrottleStatus, fmt.Errorf("graphql: %s", respBody.Errors[0].Message)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody.Data, out); err != nil {
+			return respBody.Extensions.Cost.ThrottleStatus, err
+		}
+	}
+
+	return respBody.Extensions.Cost.ThrottleStatus, nil
+}
+
+// Query executes a GraphQL query document and decodes its `data` field into out.
+func (s *GraphQLServiceOp) Query(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	_, err := s.do(ctx, query, variables, out)
+	return err
+}
+
+// Mutate executes a GraphQL mutation document and decodes its `data` field into out.
+func (s *GraphQLServiceOp) Mutate(ctx context.Context, mutation string, variables map[string]interface{}, out interface{}) error {
+	_, err := s.do(ctx, mutation, variables, out)
+	return err
+}
+
+// QueryAll repeatedly executes query, injecting an "after" variable from the
+// previous page's cursor, until pager reports there is no next page. It is
+// meant for connection-shaped queries that are too large to express as a
+// single REST ListWithPagination call.
+func (s *GraphQLServiceOp) QueryAll(ctx context.Context, query string, variables map[string]interface{}, pager GraphQLPager) error {
+	vars := make(map[string]interface{}, len(variables)+1)
+	for k, v := range variables {
+		vars[k] = v
+	}
+
+	for {
+		var data json.RawMessage
+		throttle, err := s.do(ctx, query, vars, &data)
+		if err != nil {
+			return err
+		}
+
+		pageInfo, err := pager.Decode(data)
+		if err != nil {
+			return err
+		}
+
+		if !pageInfo.HasNextPage {
+			return nil
+		}
+
+		vars["after"] = pageInfo.EndCursor
+
+		// Back off when the GraphQL cost bucket is nearly exhausted rather
+		// than firing the next page immediately and eating a 429.
+		if wait := throttleBackoff(throttle); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// throttleBackoff returns how long to wait before the next bulk page so the
+// GraphQL cost bucket can refill, or zero if there is plenty of budget left.
+func throttleBackoff(status GraphQLThrottleStatus) time.Duration {
+	if status.RestoreRate <= 0 || status.CurrentlyAvailable >= status.MaximumAvailable*0.1 {
+		return 0
+	}
+	needed := status.MaximumAvailable*0.1 - status.CurrentlyAvailable
+	return time.Duration(needed/status.RestoreRate*1000) * time.Millisecond
+}
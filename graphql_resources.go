@@ -0,0 +1,81 @@
+package goshopify
+
+import "time"
+
+// The types below are thin GraphQL counterparts to the REST Product and
+// Payout resources. They only cover the fields most callers migrating off
+// ProductServiceOp/PayoutsServiceOp need; request additional fields directly
+// in your own query document and decode into your own struct if you need
+// more.
+
+// GraphQLProduct is the `data.product` (or a `ProductConnection` node) shape
+// for the fields that line up with Product.
+type GraphQLProduct struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Handle      string `json:"handle"`
+	Vendor      string `json:"vendor"`
+	ProductType string `json:"productType"`
+	Status      string `json:"status"`
+}
+
+// GraphQLProductsConnection is the `data.products` shape of a paginated
+// products query, suitable for use with GraphQLService.QueryAll.
+type GraphQLProductsConnection struct {
+	Products struct {
+		Edges []struct {
+			Cursor string         `json:"cursor"`
+			Node   GraphQLProduct `json:"node"`
+		} `json:"edges"`
+		PageInfo GraphQLPageInfo `json:"pageInfo"`
+	} `json:"products"`
+}
+
+// GraphQLOrder is the `data.order` (or an `OrderConnection` node) shape for
+// the fields most reconciliation and reporting code needs.
+type GraphQLOrder struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+}
+
+// IsCancelled reports whether the order has been cancelled.
+func (o GraphQLOrder) IsCancelled() bool {
+	return o.CancelledAt != nil
+}
+
+// GraphQLOrdersConnection is the `data.orders` shape of a paginated orders query.
+type GraphQLOrdersConnection struct {
+	Orders struct {
+		Edges []struct {
+			Cursor string       `json:"cursor"`
+			Node   GraphQLOrder `json:"node"`
+		} `json:"edges"`
+		PageInfo GraphQLPageInfo `json:"pageInfo"`
+	} `json:"orders"`
+}
+
+// GraphQLPayout is the `data.shopifyPaymentsAccount.payouts` node shape;
+// Shopify only exposes payouts via the ShopifyPaymentsAccount GraphQL root,
+// there is no top-level `payout` field.
+type GraphQLPayout struct {
+	ID       string `json:"id"`
+	Issued   string `json:"issuedAt"`
+	Status   string `json:"status"`
+	Currency string `json:"currencyCode"`
+}
+
+// GraphQLPayoutsConnection is the `data.shopifyPaymentsAccount.payouts` shape
+// of a paginated payouts query.
+type GraphQLPayoutsConnection struct {
+	ShopifyPaymentsAccount struct {
+		Payouts struct {
+			Edges []struct {
+				Cursor string        `json:"cursor"`
+				Node   GraphQLPayout `json:"node"`
+			} `json:"edges"`
+			PageInfo GraphQLPageInfo `json:"pageInfo"`
+		} `json:"payouts"`
+	} `json:"shopifyPaymentsAccount"`
+}
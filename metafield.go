@@ -0,0 +1,154 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const metafieldsBasePath = "metafields"
+
+// MetafieldsService is an interface for interfacing with the metafield
+// endpoints of the Shopify API. Resources that carry metafields (Product,
+// Order, ...) embed this interface and satisfy it via a MetafieldServiceOp
+// scoped to their own resource name and ID.
+// See: https://help.shopify.com/api/reference/metafield
+type MetafieldsService interface {
+	ListMetafields(int64, interface{}) ([]Metafield, error)
+	ListMetafieldsContext(context.Context, int64, interface{}) ([]Metafield, error)
+	CountMetafields(int64, interface{}) (int, error)
+	CountMetafieldsContext(context.Context, int64, interface{}) (int, error)
+	GetMetafield(int64, int64, interface{}) (*Metafield, error)
+	GetMetafieldContext(context.Context, int64, int64, interface{}) (*Metafield, error)
+	CreateMetafield(int64, Metafield) (*Metafield, error)
+	CreateMetafieldContext(context.Context, int64, Metafield) (*Metafield, error)
+	UpdateMetafield(int64, Metafield) (*Metafield, error)
+	UpdateMetafieldContext(context.Context, int64, Metafield) (*Metafield, error)
+	DeleteMetafield(int64, int64) error
+	DeleteMetafieldContext(context.Context, int64, int64) error
+}
+
+// MetafieldServiceOp handles communication with the metafield related
+// methods of the Shopify API, scoped to a single owning resource (e.g. one
+// product).
+type MetafieldServiceOp struct {
+	client     *Client
+	resource   string
+	resourceID int64
+}
+
+// Metafield represents a Shopify metafield.
+type Metafield struct {
+	ID                int64       `json:"id,omitempty"`
+	Key               string      `json:"key,omitempty"`
+	Value             interface{} `json:"value,omitempty"`
+	Type              string      `json:"type,omitempty"`
+	Namespace         string      `json:"namespace,omitempty"`
+	Description       string      `json:"description,omitempty"`
+	OwnerId           int64       `json:"owner_id,omitempty"`
+	CreatedAt         *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time  `json:"updated_at,omitempty"`
+	OwnerResource     string      `json:"owner_resource,omitempty"`
+	AdminGraphqlAPIID string      `json:"admin_graphql_api_id,omitempty"`
+}
+
+// MetafieldResource represents the result from the metafields/X.json endpoint.
+type MetafieldResource struct {
+	Metafield *Metafield `json:"metafield"`
+}
+
+// MetafieldsResource represents the result from the metafields.json endpoint.
+type MetafieldsResource struct {
+	Metafields []Metafield `json:"metafields"`
+}
+
+func (s *MetafieldServiceOp) basePath() string {
+	return fmt.Sprintf("%s/%d/%s", s.resource, s.resourceID, metafieldsBasePath)
+}
+
+// List metafields for the owning resource.
+//
+// Deprecated: use ListContext instead.
+func (s *MetafieldServiceOp) List(options interface{}) ([]Metafield, error) {
+	return s.ListContext(context.Background(), options)
+}
+
+// ListContext lists metafields for the owning resource.
+func (s *MetafieldServiceOp) ListContext(ctx context.Context, options interface{}) ([]Metafield, error) {
+	path := fmt.Sprintf("%s.json", s.basePath())
+	resource := new(MetafieldsResource)
+	err := s.client.GetContext(ctx, path, resource, options)
+	return resource.Metafields, err
+}
+
+// Count metafields for the owning resource.
+//
+// Deprecated: use CountContext instead.
+func (s *MetafieldServiceOp) Count(options interface{}) (int, error) {
+	return s.CountContext(context.Background(), options)
+}
+
+// CountContext counts metafields for the owning resource.
+func (s *MetafieldServiceOp) CountContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/count.json", s.basePath())
+	return s.client.CountContext(ctx, path, options)
+}
+
+// Get an individual metafield for the owning resource.
+//
+// Deprecated: use GetContext instead.
+func (s *MetafieldServiceOp) Get(metafieldID int64, options interface{}) (*Metafield, error) {
+	return s.GetContext(context.Background(), metafieldID, options)
+}
+
+// GetContext gets an individual metafield for the owning resource.
+func (s *MetafieldServiceOp) GetContext(ctx context.Context, metafieldID int64, options interface{}) (*Metafield, error) {
+	path := fmt.Sprintf("%s/%d.json", s.basePath(), metafieldID)
+	resource := new(MetafieldResource)
+	err := s.client.GetContext(ctx, path, resource, options)
+	return resource.Metafield, err
+}
+
+// Create a new metafield for the owning resource.
+//
+// Deprecated: use CreateContext instead.
+func (s *MetafieldServiceOp) Create(metafield Metafield) (*Metafield, error) {
+	return s.CreateContext(context.Background(), metafield)
+}
+
+// CreateContext creates a new metafield for the owning resource.
+func (s *MetafieldServiceOp) CreateContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	path := fmt.Sprintf("%s.json", s.basePath())
+	wrappedData := MetafieldResource{Metafield: &metafield}
+	resource := new(MetafieldResource)
+	err := s.client.PostContext(ctx, path, wrappedData, resource)
+	return resource.Metafield, err
+}
+
+// Update an existing metafield for the owning resource.
+//
+// Deprecated: use UpdateContext instead.
+func (s *MetafieldServiceOp) Update(metafield Metafield) (*Metafield, error) {
+	return s.UpdateContext(context.Background(), metafield)
+}
+
+// UpdateContext updates an existing metafield for the owning resource.
+func (s *MetafieldServiceOp) UpdateContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	path := fmt.Sprintf("%s/%d.json", s.basePath(), metafield.ID)
+	wrappedData := MetafieldResource{Metafield: &metafield}
+	resource := new(MetafieldResource)
+	err := s.client.PutContext(ctx, path, wrappedData, resource)
+	return resource.Metafield, err
+}
+
+// Delete an existing metafield for the owning resource.
+//
+// Deprecated: use DeleteContext instead.
+func (s *MetafieldServiceOp) Delete(metafieldID int64) error {
+	return s.DeleteContext(context.Background(), metafieldID)
+}
+
+// DeleteContext deletes an existing metafield for the owning resource.
+func (s *MetafieldServiceOp) DeleteContext(ctx context.Context, metafieldID int64) error {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("%s/%d.json", s.basePath(), metafieldID))
+}
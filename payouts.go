@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -17,9 +18,24 @@ const (
 // See: https://shopify.dev/docs/api/admin-rest/2023-01/resources/payouts
 type PayoutsService interface {
 	List(interface{}) ([]Payout, error)
+	ListContext(context.Context, interface{}) ([]Payout, error)
 	ListWithPagination(interface{}) ([]Payout, *Pagination, error)
+	ListWithPaginationContext(context.Context, interface{}) ([]Payout, *Pagination, error)
 	Get(int64, interface{}) (*Payout, error)
+	GetContext(context.Context, int64, interface{}) (*Payout, error)
 	TransactionsForPayout(int64) ([]PayoutTransaction, error)
+	TransactionsForPayoutContext(context.Context, int64) ([]PayoutTransaction, error)
+
+	// ListBulk and TransactionsBulk run the equivalent REST call through a
+	// Shopify bulk operation instead of paginating. See ListBulk's doc
+	// comment in bulk_operations.go for when to prefer it.
+	ListBulk(context.Context) (<-chan Payout, <-chan error)
+	TransactionsBulk(context.Context, int64) (<-chan PayoutTransaction, <-chan error)
+
+	// Reconcile aggregates a payout's transactions by type and by order,
+	// and cross-checks the total against the payout's reported amount. See
+	// reconcile.go.
+	Reconcile(ctx context.Context, payoutID int64, resolveOrder func(sourceOrderID int64) (orderName string, err error)) (*PayoutReconciliation, error)
 }
 
 // PayoutsServiceOp handles communication with the payout related methods of the
@@ -75,19 +91,34 @@ type PayoutTransactionsResource struct {
 }
 
 // List payouts
+//
+// Deprecated: use ListContext instead.
 func (s *PayoutsServiceOp) List(options interface{}) ([]Payout, error) {
-	payouts, _, err := s.ListWithPagination(options)
+	return s.ListContext(context.Background(), options)
+}
+
+// ListContext lists payouts.
+func (s *PayoutsServiceOp) ListContext(ctx context.Context, options interface{}) ([]Payout, error) {
+	payouts, _, err := s.ListWithPaginationContext(ctx, options)
 	if err != nil {
 		return nil, err
 	}
 	return payouts, nil
 }
 
+// ListWithPagination lists payouts and returns pagination to retrieve next/previous results.
+//
+// Deprecated: use ListWithPaginationContext instead.
 func (s *PayoutsServiceOp) ListWithPagination(options interface{}) ([]Payout, *Pagination, error) {
+	return s.ListWithPaginationContext(context.Background(), options)
+}
+
+// ListWithPaginationContext lists payouts and returns pagination to retrieve next/previous results.
+func (s *PayoutsServiceOp) ListWithPaginationContext(ctx context.Context, options interface{}) ([]Payout, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", payoutsBasePath)
 	resource := new(PayoutsResource)
 
-	pagination, err := s.client.ListWithPagination(path, resource, options)
+	pagination, err := s.client.ListWithPaginationContext(ctx, path, resource, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -96,24 +127,48 @@ func (s *PayoutsServiceOp) ListWithPagination(options interface{}) ([]Payout, *P
 }
 
 // Get individual payout
+//
+// Deprecated: use GetContext instead.
 func (s *PayoutsServiceOp) Get(id int64, options interface{}) (*Payout, error) {
+	return s.GetContext(context.Background(), id, options)
+}
+
+// GetContext gets an individual payout.
+func (s *PayoutsServiceOp) GetContext(ctx context.Context, id int64, options interface{}) (*Payout, error) {
 	path := fmt.Sprintf("%s/%d.json", payoutsBasePath, id)
 	resource := new(PayoutResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetContext(ctx, path, resource, options)
 	return resource.Payout, err
 }
 
 // TransactionsForPayout load all transactions for given payout ID
+//
+// Deprecated: use TransactionsForPayoutContext instead.
 func (s *PayoutsServiceOp) TransactionsForPayout(payoutID int64) ([]PayoutTransaction, error) {
+	return s.TransactionsForPayoutContext(context.Background(), payoutID)
+}
+
+// TransactionsForPayoutContext loads all transactions for given payout ID.
+// Unlike TransactionsForPayout, it can be cancelled or given a deadline,
+// which matters since a payout with a large number of transactions can take
+// several pagination round-trips to fully load.
+func (s *PayoutsServiceOp) TransactionsForPayoutContext(ctx context.Context, payoutID int64) ([]PayoutTransaction, error) {
 	path := fmt.Sprintf("%s.json?payout_id=%d", payoutTransactionsBasePath, payoutID)
-	resource := new(PayoutTransactionsResource)
 
-	_, err := s.client.ListWithPagination(path, resource, nil)
-	if err != nil {
-		return nil, err
+	var transactions []PayoutTransaction
+	var options interface{}
+	for {
+		resource := new(PayoutTransactionsResource)
+		pagination, err := s.client.ListWithPaginationContext(ctx, path, resource, options)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, resource.Transactions...)
+		if pagination.NextPageOptions == nil {
+			return transactions, nil
+		}
+		options = pagination.NextPageOptions
 	}
-
-	return resource.Transactions, nil
 }
 
 type PayoutTransaction struct {
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"time"
@@ -17,12 +18,24 @@ var linkRegex = regexp.MustCompile(`^ *<([^>]+)>; rel="(previous|next)" *$`)
 // See: https://help.shopify.com/api/reference/product
 type ProductService interface {
 	List(interface{}) ([]Product, error)
+	ListContext(context.Context, interface{}) ([]Product, error)
 	ListWithPagination(interface{}) ([]Product, *Pagination, error)
+	ListWithPaginationContext(context.Context, interface{}) ([]Product, *Pagination, error)
 	Count(interface{}) (int, error)
+	CountContext(context.Context, interface{}) (int, error)
 	Get(int64, interface{}) (*Product, error)
+	GetContext(context.Context, int64, interface{}) (*Product, error)
 	Create(Product) (*Product, error)
+	CreateContext(context.Context, Product) (*Product, error)
 	Update(Product) (*Product, error)
+	UpdateContext(context.Context, Product) (*Product, error)
 	Delete(int64) error
+	DeleteContext(context.Context, int64) error
+
+	// ListBulk runs products through a Shopify bulk operation instead of
+	// paginating via ListWithPaginationContext. See ListBulk's doc comment
+	// in bulk_operations.go for when to prefer it.
+	ListBulk(context.Context, ProductListOptions) (<-chan Product, <-chan error)
 
 	// MetafieldsService used for Product resource to communicate with Metafields resource
 	MetafieldsService
@@ -97,8 +110,15 @@ type Pagination struct {
 }
 
 // List products
+//
+// Deprecated: use ListContext instead.
 func (s *ProductServiceOp) List(options interface{}) ([]Product, error) {
-	products, _, err := s.ListWithPagination(options)
+	return s.ListContext(context.Background(), options)
+}
+
+// ListContext lists products.
+func (s *ProductServiceOp) ListContext(ctx context.Context, options interface{}) ([]Product, error) {
+	products, _, err := s.ListWithPaginationContext(ctx, options)
 	if err != nil {
 		return nil, err
 	}
@@ -106,11 +126,18 @@ func (s *ProductServiceOp) List(options interface{}) ([]Product, error) {
 }
 
 // ListWithPagination lists products and return pagination to retrieve next/previous results.
+//
+// Deprecated: use ListWithPaginationContext instead.
 func (s *ProductServiceOp) ListWithPagination(options interface{}) ([]Product, *Pagination, error) {
+	return s.ListWithPaginationContext(context.Background(), options)
+}
+
+// ListWithPaginationContext lists products and return pagination to retrieve next/previous results.
+func (s *ProductServiceOp) ListWithPaginationContext(ctx context.Context, options interface{}) ([]Product, *Pagination, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
 	resource := new(ProductsResource)
 
-	pagination, err := s.client.ListWithPagination(path, resource, options)
+	pagination, err := s.client.ListWithPaginationContext(ctx, path, resource, options)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -119,74 +146,151 @@ func (s *ProductServiceOp) ListWithPagination(options interface{}) ([]Product, *
 }
 
 // Count products
+//
+// Deprecated: use CountContext instead.
 func (s *ProductServiceOp) Count(options interface{}) (int, error) {
+	return s.CountContext(context.Background(), options)
+}
+
+// CountContext counts products.
+func (s *ProductServiceOp) CountContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", productsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountContext(ctx, path, options)
 }
 
 // Get individual product
+//
+// Deprecated: use GetContext instead.
 func (s *ProductServiceOp) Get(productID int64, options interface{}) (*Product, error) {
+	return s.GetContext(context.Background(), productID, options)
+}
+
+// GetContext gets an individual product.
+func (s *ProductServiceOp) GetContext(ctx context.Context, productID int64, options interface{}) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, productID)
 	resource := new(ProductResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetContext(ctx, path, resource, options)
 	return resource.Product, err
 }
 
 // Create a new product
+//
+// Deprecated: use CreateContext instead.
 func (s *ProductServiceOp) Create(product Product) (*Product, error) {
+	return s.CreateContext(context.Background(), product)
+}
+
+// CreateContext creates a new product.
+func (s *ProductServiceOp) CreateContext(ctx context.Context, product Product) (*Product, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostContext(ctx, path, wrappedData, resource)
 	return resource.Product, err
 }
 
 // Update an existing product
+//
+// Deprecated: use UpdateContext instead.
 func (s *ProductServiceOp) Update(product Product) (*Product, error) {
+	return s.UpdateContext(context.Background(), product)
+}
+
+// UpdateContext updates an existing product.
+func (s *ProductServiceOp) UpdateContext(ctx context.Context, product Product) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, product.ID)
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutContext(ctx, path, wrappedData, resource)
 	return resource.Product, err
 }
 
 // Delete an existing product
+//
+// Deprecated: use DeleteContext instead.
 func (s *ProductServiceOp) Delete(productID int64) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", productsBasePath, productID))
+	return s.DeleteContext(context.Background(), productID)
+}
+
+// DeleteContext deletes an existing product.
+func (s *ProductServiceOp) DeleteContext(ctx context.Context, productID int64) error {
+	return s.client.DeleteContext(ctx, fmt.Sprintf("%s/%d.json", productsBasePath, productID))
 }
 
 // ListMetafields for a product
+//
+// Deprecated: use ListMetafieldsContext instead.
 func (s *ProductServiceOp) ListMetafields(productID int64, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsContext(context.Background(), productID, options)
+}
+
+// ListMetafieldsContext lists metafields for a product.
+func (s *ProductServiceOp) ListMetafieldsContext(ctx context.Context, productID int64, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.List(options)
+	return metafieldService.ListContext(ctx, options)
 }
 
 // Count metafields for a product
+//
+// Deprecated: use CountMetafieldsContext instead.
 func (s *ProductServiceOp) CountMetafields(productID int64, options interface{}) (int, error) {
+	return s.CountMetafieldsContext(context.Background(), productID, options)
+}
+
+// CountMetafieldsContext counts metafields for a product.
+func (s *ProductServiceOp) CountMetafieldsContext(ctx context.Context, productID int64, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Count(options)
+	return metafieldService.CountContext(ctx, options)
 }
 
 // GetMetafield for a product
+//
+// Deprecated: use GetMetafieldContext instead.
 func (s *ProductServiceOp) GetMetafield(productID int64, metafieldID int64, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldContext(context.Background(), productID, metafieldID, options)
+}
+
+// GetMetafieldContext gets an individual metafield for a product.
+func (s *ProductServiceOp) GetMetafieldContext(ctx context.Context, productID int64, metafieldID int64, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetContext(ctx, metafieldID, options)
 }
 
 // CreateMetafield for a product
+//
+// Deprecated: use CreateMetafieldContext instead.
 func (s *ProductServiceOp) CreateMetafield(productID int64, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldContext(context.Background(), productID, metafield)
+}
+
+// CreateMetafieldContext creates a new metafield for a product.
+func (s *ProductServiceOp) CreateMetafieldContext(ctx context.Context, productID int64, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateContext(ctx, metafield)
 }
 
 // UpdateMetafield for a product
+//
+// Deprecated: use UpdateMetafieldContext instead.
 func (s *ProductServiceOp) UpdateMetafield(productID int64, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldContext(context.Background(), productID, metafield)
+}
+
+// UpdateMetafieldContext updates an existing metafield for a product.
+func (s *ProductServiceOp) UpdateMetafieldContext(ctx context.Context, productID int64, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateContext(ctx, metafield)
 }
 
 // DeleteMetafield for a product
+//
+// Deprecated: use DeleteMetafieldContext instead.
 func (s *ProductServiceOp) DeleteMetafield(productID int64, metafieldID int64) error {
+	return s.DeleteMetafieldContext(context.Background(), productID, metafieldID)
+}
+
+// DeleteMetafieldContext deletes an existing metafield for a product.
+func (s *ProductServiceOp) DeleteMetafieldContext(ctx context.Context, productID int64, metafieldID int64) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteContext(ctx, metafieldID)
 }
@@ -0,0 +1,144 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// shopifyAPICallLimitHeader carries the shop's REST leaky-bucket usage as
+// "used/max", e.g. "32/40".
+const shopifyAPICallLimitHeader = "X-Shopify-Shop-Api-Call-Limit"
+
+// RateLimiter paces outgoing requests against Shopify's per-shop leaky
+// bucket. Client.send calls Wait before every request and Update after
+// every response that carries a parseable X-Shopify-Shop-Api-Call-Limit
+// header. The default implementation, newLeakyBucketLimiter, keeps
+// its estimate in-process; callers running multiple processes against the
+// same shop can supply their own (e.g. backed by Redis) via WithRateLimiter.
+type RateLimiter interface {
+	// Wait blocks until the caller may safely send another request, or
+	// returns ctx.Err() if ctx is done first.
+	Wait(ctx context.Context) error
+	// Update records the bucket usage observed on a response.
+	Update(used, max int)
+}
+
+// WithRateLimiter overrides the Client's default in-process leaky-bucket
+// limiter, e.g. with a distributed limiter shared across worker processes.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// leakyBucketLimiter is the default RateLimiter. It mirrors Shopify's REST
+// leaky bucket: a fixed capacity that drains on every request and refills
+// at a fixed rate. Rather than waiting for a 429, it paces requests once the
+// bucket gets close to full.
+type leakyBucketLimiter struct {
+	mu           sync.Mutex
+	capacity     int
+	used         int
+	leakInterval time.Duration
+	lastUpdate   time.Time
+}
+
+// newLeakyBucketLimiter returns a RateLimiter modeling Shopify's standard
+// REST bucket (40 capacity, leaking 2/sec), which is the default for
+// non-Plus stores. Capacity is corrected from the X-Shopify-Shop-Api-Call-Limit
+// header as soon as a response is seen, so Plus shops (bucket size 80) are
+// handled automatically.
+func newLeakyBucketLimiter() *leakyBucketLimiter {
+	return &leakyBucketLimiter{
+		capacity:     40,
+		leakInterval: 500 * time.Millisecond,
+		lastUpdate:   time.Time{},
+	}
+}
+
+func (l *leakyBucketLimiter) Update(used, max int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.capacity = max
+	l.used = used
+	l.lastUpdate = time.Now()
+}
+
+// Wait blocks while the bucket has fewer than one slot of headroom left.
+func (l *leakyBucketLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		// Account for leakage since the last observed response so we don't
+		// keep stalling on a stale "almost full" reading.
+		if !l.lastUpdate.IsZero() {
+			leaked := int(time.Since(l.lastUpdate) / l.leakInterval)
+			if leaked > 0 {
+				l.used -= leaked
+				if l.used < 0 {
+					l.used = 0
+				}
+				l.lastUpdate = l.lastUpdate.Add(time.Duration(leaked) * l.leakInterval)
+			}
+		}
+
+		if l.used < l.capacity {
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-time.After(l.leakInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// parseAPICallLimit parses the "used/max" shape of the
+// X-Shopify-Shop-Api-Call-Limit header. It returns ok=false if the header is
+// missing or malformed, which the caller should treat as "no update".
+func parseAPICallLimit(header http.Header) (used, max int, ok bool) {
+	raw := header.Get(shopifyAPICallLimitHeader)
+	if raw == "" {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(raw, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	used, errUsed := strconv.Atoi(strings.TrimSpace(parts[0]))
+	max, errMax := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if errUsed != nil || errMax != nil {
+		return 0, 0, false
+	}
+	return used, max, true
+}
+
+// retryAfterDelay parses a Retry-After header (seconds, per Shopify's 429
+// response) and applies exponential backoff on top for subsequent attempts
+// of the same request. attempt is zero-based.
+func retryAfterDelay(header http.Header, attempt int) time.Duration {
+	delay := time.Second
+	if raw := header.Get("Retry-After"); raw != "" {
+		if secs, err := strconv.ParseFloat(raw, 64); err == nil && secs > 0 {
+			delay = time.Duration(secs * float64(time.Second))
+		}
+	}
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+	}
+	const maxBackoff = 30 * time.Second
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// maxRetries bounds how many times Client.send retries a request that keeps
+// coming back 429 after backing off.
+const maxRetries = 5
@@ -0,0 +1,167 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/shopspring/decimal"
+)
+
+// PayoutReconciliationTotal aggregates amount/fee/net across a group of
+// payout transactions.
+type PayoutReconciliationTotal struct {
+	Count  int             `json:"count"`
+	Amount decimal.Decimal `json:"amount"`
+	Fee    decimal.Decimal `json:"fee"`
+	Net    decimal.Decimal `json:"net"`
+}
+
+func (t *PayoutReconciliationTotal) add(txn PayoutTransaction) {
+	t.Count++
+	t.Amount = t.Amount.Add(txn.Amount)
+	t.Fee = t.Fee.Add(txn.Fee)
+	t.Net = t.Net.Add(txn.Net)
+}
+
+// PayoutReconciliationOrder is the per-order breakdown of a payout: every
+// transaction sourced from a single order, totalled, with the order's name
+// filled in if Reconcile was given a resolveOrder func.
+type PayoutReconciliationOrder struct {
+	SourceOrderID int64                     `json:"source_order_id"`
+	OrderName     string                    `json:"order_name,omitempty"`
+	Transactions  []PayoutTransaction       `json:"transactions"`
+	Total         PayoutReconciliationTotal `json:"total"`
+}
+
+// PayoutReconciliation is the result of PayoutsServiceOp.Reconcile: the
+// payout's transactions aggregated by type and by order, cross-checked
+// against the payout's reported amount.
+type PayoutReconciliation struct {
+	Payout Payout `json:"payout"`
+
+	// Totals groups every transaction on the payout by its Type (charge,
+	// refund, adjustment, fee, adjustment_reversal, etc.) and sums Amount,
+	// Fee, and Net within each group.
+	Totals map[string]PayoutReconciliationTotal `json:"totals"`
+
+	// ByOrder groups every transaction that has a SourceOrderID by that
+	// order, keyed on it. Transactions with no SourceOrderID (e.g. payout
+	// fees) are omitted.
+	ByOrder map[int64]PayoutReconciliationOrder `json:"by_order"`
+
+	// GrandTotal sums Totals across every type.
+	GrandTotal PayoutReconciliationTotal `json:"grand_total"`
+
+	// Discrepancy is Payout.Amount minus GrandTotal.Net. It should be zero;
+	// a non-zero value means either the transactions didn't all get fetched
+	// or Shopify's payout amount and transaction ledger disagree.
+	Discrepancy decimal.Decimal `json:"discrepancy"`
+
+	Transactions []PayoutTransaction `json:"transactions"`
+}
+
+// Reconcile fetches every transaction for payoutID and aggregates them by
+// type and by order, cross-checking the total against the payout's reported
+// amount. This is the most common reason callers pull payout transactions in
+// the first place, so it is provided as a single call instead of requiring
+// every user to hand-roll the aggregation over TransactionsForPayoutContext.
+//
+// resolveOrder, if non-nil, is called once per distinct SourceOrderID to
+// look up a human-readable order name (e.g. from the orders endpoint or a
+// local orders cache) to fill in PayoutReconciliationOrder.OrderName; this
+// is an optional lazy fetch so Reconcile itself never fetches orders. Pass
+// nil to skip it and key purely on SourceOrderID.
+func (s *PayoutsServiceOp) Reconcile(ctx context.Context, payoutID int64, resolveOrder func(sourceOrderID int64) (orderName string, err error)) (*PayoutReconciliation, error) {
+	payout, err := s.GetContext(ctx, payoutID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.TransactionsForPayoutContext(ctx, payoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateTransactions(*payout, transactions, resolveOrder)
+}
+
+// aggregateTransactions does the actual grouping-and-summing work behind
+// Reconcile, split out so it can be exercised without a live Client.
+func aggregateTransactions(payout Payout, transactions []PayoutTransaction, resolveOrder func(sourceOrderID int64) (orderName string, err error)) (*PayoutReconciliation, error) {
+	result := &PayoutReconciliation{
+		Payout:       payout,
+		Totals:       make(map[string]PayoutReconciliationTotal),
+		ByOrder:      make(map[int64]PayoutReconciliationOrder),
+		Transactions: transactions,
+	}
+
+	for _, txn := range transactions {
+		total := result.Totals[txn.Type]
+		total.add(txn)
+		result.Totals[txn.Type] = total
+
+		result.GrandTotal.add(txn)
+
+		if txn.SourceOrderID == 0 {
+			continue
+		}
+
+		order, ok := result.ByOrder[txn.SourceOrderID]
+		if !ok {
+			order.SourceOrderID = txn.SourceOrderID
+			if resolveOrder != nil {
+				name, err := resolveOrder(txn.SourceOrderID)
+				if err != nil {
+					return nil, fmt.Errorf("resolve order %d: %w", txn.SourceOrderID, err)
+				}
+				order.OrderName = name
+			}
+		}
+		order.Transactions = append(order.Transactions, txn)
+		order.Total.add(txn)
+		result.ByOrder[txn.SourceOrderID] = order
+	}
+
+	result.Discrepancy = payout.Amount.Sub(result.GrandTotal.Net)
+
+	return result, nil
+}
+
+// ReconcileCSV writes one row per transaction to w as a bookkeeping-ready
+// CSV: order, type, currency, gross, fee, net. The order column uses the
+// OrderName resolved into r.ByOrder by Reconcile, falling back to the
+// numeric source order ID if none was resolved.
+func (r *PayoutReconciliation) ReconcileCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"order", "type", "currency", "gross", "fee", "net"}); err != nil {
+		return err
+	}
+
+	for _, txn := range r.Transactions {
+		order := ""
+		if txn.SourceOrderID != 0 {
+			order = fmt.Sprintf("%d", txn.SourceOrderID)
+			if o, ok := r.ByOrder[txn.SourceOrderID]; ok && o.OrderName != "" {
+				order = o.OrderName
+			}
+		}
+
+		row := []string{
+			order,
+			txn.Type,
+			txn.Currency,
+			txn.Amount.String(),
+			txn.Fee.String(),
+			txn.Net.String(),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,165 @@
+package goshopify
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// errWriter fails every Write, simulating an underlying io.Writer (e.g. a
+// closed network connection) that only errors once csv.Writer's internal
+// buffer actually gets flushed to it.
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func decimalFromString(t *testing.T, s string) decimal.Decimal {
+	t.Helper()
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		t.Fatalf("decimal.NewFromString(%q): %v", s, err)
+	}
+	return d
+}
+
+func TestAggregateTransactionsTotalsAndDiscrepancy(t *testing.T) {
+	payout := Payout{Id: 1, Amount: decimalFromString(t, "190.00")}
+	transactions := []PayoutTransaction{
+		{Type: "charge", Amount: decimalFromString(t, "100.00"), Fee: decimalFromString(t, "3.00"), Net: decimalFromString(t, "97.00"), SourceOrderID: 10},
+		{Type: "charge", Amount: decimalFromString(t, "100.00"), Fee: decimalFromString(t, "3.00"), Net: decimalFromString(t, "97.00"), SourceOrderID: 11},
+		{Type: "refund", Amount: decimalFromString(t, "-4.00"), Fee: decimalFromString(t, "0.00"), Net: decimalFromString(t, "-4.00"), SourceOrderID: 10},
+	}
+
+	result, err := aggregateTransactions(payout, transactions, nil)
+	if err != nil {
+		t.Fatalf("aggregateTransactions() error = %v", err)
+	}
+
+	if got, want := result.Totals["charge"].Count, 2; got != want {
+		t.Errorf("Totals[charge].Count = %d, want %d", got, want)
+	}
+	if got, want := result.Totals["charge"].Net.String(), "194"; got != want {
+		t.Errorf("Totals[charge].Net = %s, want %s", got, want)
+	}
+	if got, want := result.GrandTotal.Net.String(), "190"; got != want {
+		t.Errorf("GrandTotal.Net = %s, want %s", got, want)
+	}
+	if !result.Discrepancy.IsZero() {
+		t.Errorf("Discrepancy = %s, want 0", result.Discrepancy.String())
+	}
+
+	order10, ok := result.ByOrder[10]
+	if !ok {
+		t.Fatal("ByOrder[10] missing")
+	}
+	if got, want := order10.Total.Net.String(), "93"; got != want {
+		t.Errorf("ByOrder[10].Total.Net = %s, want %s", got, want)
+	}
+	if len(order10.Transactions) != 2 {
+		t.Errorf("ByOrder[10].Transactions = %d, want 2", len(order10.Transactions))
+	}
+
+	if _, ok := result.ByOrder[11]; !ok {
+		t.Error("ByOrder[11] missing")
+	}
+}
+
+func TestAggregateTransactionsSkipsZeroSourceOrderID(t *testing.T) {
+	payout := Payout{Id: 1, Amount: decimalFromString(t, "-2.00")}
+	transactions := []PayoutTransaction{
+		{Type: "payout", Amount: decimalFromString(t, "-2.00"), Net: decimalFromString(t, "-2.00")},
+	}
+
+	result, err := aggregateTransactions(payout, transactions, nil)
+	if err != nil {
+		t.Fatalf("aggregateTransactions() error = %v", err)
+	}
+	if len(result.ByOrder) != 0 {
+		t.Errorf("ByOrder = %v, want empty", result.ByOrder)
+	}
+}
+
+func TestAggregateTransactionsResolveOrder(t *testing.T) {
+	payout := Payout{Id: 1}
+	transactions := []PayoutTransaction{
+		{Type: "charge", SourceOrderID: 10},
+		{Type: "charge", SourceOrderID: 10},
+	}
+
+	calls := 0
+	resolveOrder := func(sourceOrderID int64) (string, error) {
+		calls++
+		return "#1001", nil
+	}
+
+	result, err := aggregateTransactions(payout, transactions, resolveOrder)
+	if err != nil {
+		t.Fatalf("aggregateTransactions() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("resolveOrder called %d times, want 1", calls)
+	}
+	if got, want := result.ByOrder[10].OrderName, "#1001"; got != want {
+		t.Errorf("ByOrder[10].OrderName = %q, want %q", got, want)
+	}
+}
+
+func TestAggregateTransactionsResolveOrderError(t *testing.T) {
+	payout := Payout{Id: 1}
+	transactions := []PayoutTransaction{{Type: "charge", SourceOrderID: 10}}
+
+	wantErr := errors.New("boom")
+	resolveOrder := func(sourceOrderID int64) (string, error) {
+		return "", wantErr
+	}
+
+	if _, err := aggregateTransactions(payout, transactions, resolveOrder); !errors.Is(err, wantErr) {
+		t.Fatalf("aggregateTransactions() error = %v, want wrapping %v", err, wantErr)
+	}
+}
+
+func TestReconcileCSV(t *testing.T) {
+	r := &PayoutReconciliation{
+		ByOrder: map[int64]PayoutReconciliationOrder{
+			10: {SourceOrderID: 10, OrderName: "#1001"},
+		},
+		Transactions: []PayoutTransaction{
+			{Type: "charge", Currency: "USD", SourceOrderID: 10, Amount: decimalFromString(t, "100.00"), Fee: decimalFromString(t, "3.00"), Net: decimalFromString(t, "97.00")},
+			{Type: "payout", Currency: "USD", Amount: decimalFromString(t, "-97.00"), Fee: decimalFromString(t, "0"), Net: decimalFromString(t, "-97.00")},
+			{Type: "charge", Currency: "USD", SourceOrderID: 12, Amount: decimalFromString(t, "50.00"), Fee: decimalFromString(t, "1.50"), Net: decimalFromString(t, "48.50")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := r.ReconcileCSV(&buf); err != nil {
+		t.Fatalf("ReconcileCSV() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "#1001,charge,USD,100,3,97") {
+		t.Errorf("output missing resolved order name row, got:\n%s", out)
+	}
+	if !strings.Contains(out, "12,charge,USD,50,1.5,48.5") {
+		t.Errorf("output missing numeric fallback order row, got:\n%s", out)
+	}
+	if !strings.Contains(out, ",payout,USD,-97,0,-97") {
+		t.Errorf("output missing orderless row, got:\n%s", out)
+	}
+}
+
+func TestReconcileCSVSurfacesFlushError(t *testing.T) {
+	r := &PayoutReconciliation{
+		Transactions: []PayoutTransaction{
+			{Type: "charge", Currency: "USD", Amount: decimalFromString(t, "1.00")},
+		},
+	}
+
+	if err := r.ReconcileCSV(errWriter{}); err == nil {
+		t.Fatal("ReconcileCSV() = nil error, want error from failed flush")
+	}
+}
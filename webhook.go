@@ -0,0 +1,86 @@
+package goshopify
+
+import "fmt"
+
+const webhooksBasePath = "webhooks"
+
+// WebhookService is an interface for interfacing with the webhook endpoints
+// of the Shopify API, used to register, list, and remove webhook
+// subscriptions. To verify and dispatch the webhook requests Shopify sends
+// to your app, see the webhooks subpackage.
+// See: https://shopify.dev/docs/api/admin-rest/2023-01/resources/webhook
+type WebhookService interface {
+	List(interface{}) ([]Webhook, error)
+	Get(int64, interface{}) (*Webhook, error)
+	Create(Webhook) (*Webhook, error)
+	Update(Webhook) (*Webhook, error)
+	Delete(int64) error
+}
+
+// WebhookServiceOp handles communication with the webhook related methods of
+// the Shopify API.
+type WebhookServiceOp struct {
+	client *Client
+}
+
+// Webhook represents a Shopify webhook subscription.
+type Webhook struct {
+	ID                  int64    `json:"id,omitempty"`
+	Address             string   `json:"address,omitempty"`
+	Topic               string   `json:"topic,omitempty"`
+	Format              string   `json:"format,omitempty"`
+	CreatedAt           *string  `json:"created_at,omitempty"`
+	UpdatedAt           *string  `json:"updated_at,omitempty"`
+	Fields              []string `json:"fields,omitempty"`
+	MetafieldNamespaces []string `json:"metafield_namespaces,omitempty"`
+	APIVersion          string   `json:"api_version,omitempty"`
+}
+
+// Represents the result from the webhooks/X.json endpoint
+type WebhookResource struct {
+	Webhook *Webhook `json:"webhook"`
+}
+
+// Represents the result from the webhooks.json endpoint
+type WebhooksResource struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// List webhook subscriptions
+func (s *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
+	path := fmt.Sprintf("%s.json", webhooksBasePath)
+	resource := new(WebhooksResource)
+	err := s.client.Get(path, resource, options)
+	return resource.Webhooks, err
+}
+
+// Get individual webhook subscription
+func (s *WebhookServiceOp) Get(webhookID int64, options interface{}) (*Webhook, error) {
+	path := fmt.Sprintf("%s/%d.json", webhooksBasePath, webhookID)
+	resource := new(WebhookResource)
+	err := s.client.Get(path, resource, options)
+	return resource.Webhook, err
+}
+
+// Create a new webhook subscription
+func (s *WebhookServiceOp) Create(webhook Webhook) (*Webhook, error) {
+	path := fmt.Sprintf("%s.json", webhooksBasePath)
+	wrappedData := WebhookResource{Webhook: &webhook}
+	resource := new(WebhookResource)
+	err := s.client.Post(path, wrappedData, resource)
+	return resource.Webhook, err
+}
+
+// Update an existing webhook subscription
+func (s *WebhookServiceOp) Update(webhook Webhook) (*Webhook, error) {
+	path := fmt.Sprintf("%s/%d.json", webhooksBasePath, webhook.ID)
+	wrappedData := WebhookResource{Webhook: &webhook}
+	resource := new(WebhookResource)
+	err := s.client.Put(path, wrappedData, resource)
+	return resource.Webhook, err
+}
+
+// Delete an existing webhook subscription
+func (s *WebhookServiceOp) Delete(webhookID int64) error {
+	return s.client.Delete(fmt.Sprintf("%s/%d.json", webhooksBasePath, webhookID))
+}
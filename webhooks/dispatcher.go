@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	goshopify "github.com/tax-automate/go-shopify"
+)
+
+// Topic name constants for the handlers most apps register. Shopify defines
+// many more; pass any topic string to Dispatcher.HandleFunc directly if you
+// need one that isn't listed here.
+const (
+	TopicProductsCreate = "products/create"
+	TopicProductsUpdate = "products/update"
+	TopicProductsDelete = "products/delete"
+	TopicAppUninstalled = "app/uninstalled"
+)
+
+// ProductHandlerFunc handles a products/create, products/update, or
+// products/delete webhook, decoded into a goshopify.Product.
+type ProductHandlerFunc func(shopDomain string, product goshopify.Product) error
+
+// HandlerFunc handles any other webhook topic as a raw decoded payload.
+type HandlerFunc func(shopDomain string, payload json.RawMessage) error
+
+// Dispatcher verifies and routes inbound Shopify webhook requests to
+// per-topic handlers. Create one with NewDispatcher: the zero value has no
+// secret and a nil handler map, so HandleFunc on it panics.
+type Dispatcher struct {
+	secret   string
+	handlers map[string]HandlerFunc
+}
+
+// NewDispatcher returns a Dispatcher that verifies requests against secret
+// (the app's client secret) before routing them.
+func NewDispatcher(secret string) *Dispatcher {
+	return &Dispatcher{secret: secret, handlers: make(map[string]HandlerFunc)}
+}
+
+// HandleFunc registers fn for topic, Shopify's slash-separated webhook topic
+// name (e.g. "products/create").
+func (d *Dispatcher) HandleFunc(topic string, fn HandlerFunc) {
+	d.handlers[topic] = fn
+}
+
+// HandleProductFunc registers fn for topic and decodes the payload into a
+// goshopify.Product before calling it. topic should be one of
+// TopicProductsCreate, TopicProductsUpdate, or TopicProductsDelete.
+func (d *Dispatcher) HandleProductFunc(topic string, fn ProductHandlerFunc) {
+	d.HandleFunc(topic, func(shopDomain string, payload json.RawMessage) error {
+		var product goshopify.Product
+		if err := json.Unmarshal(payload, &product); err != nil {
+			return err
+		}
+		return fn(shopDomain, product)
+	})
+}
+
+// ServeHTTP verifies the request's HMAC signature, then dispatches it to the
+// handler registered for its X-Shopify-Topic header. Unknown topics and
+// verification failures are reported as the corresponding HTTP status; the
+// caller's handler error, if any, is reported as a 500.
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := VerifyRequest(d.secret, r); err != nil {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	topic := r.Header.Get("X-Shopify-Topic")
+	handler, ok := d.handlers[topic]
+	if !ok {
+		http.Error(w, fmt.Sprintf("no handler registered for topic %q", topic), http.StatusNotImplemented)
+		return
+	}
+
+	var payload json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	shopDomain := r.Header.Get("X-Shopify-Shop-Domain")
+	if err := handler(shopDomain, payload); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,69 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDispatcherRoutesToHandler(t *testing.T) {
+	const secret = "shhh"
+	d := NewDispatcher(secret)
+
+	var gotShop string
+	var gotPayload string
+	d.HandleFunc("products/create", func(shopDomain string, payload json.RawMessage) error {
+		gotShop = shopDomain
+		gotPayload = string(payload)
+		return nil
+	})
+
+	req := signedRequest(secret, `{"id":1}`)
+	req.Header.Set("X-Shopify-Topic", "products/create")
+	req.Header.Set("X-Shopify-Shop-Domain", "my-shop.myshopify.com")
+
+	rr := httptest.NewRecorder()
+	d.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusOK)
+	}
+	if gotShop != "my-shop.myshopify.com" {
+		t.Fatalf("shopDomain = %q, want my-shop.myshopify.com", gotShop)
+	}
+	if gotPayload != `{"id":1}` {
+		t.Fatalf("payload = %q, want {\"id\":1}", gotPayload)
+	}
+}
+
+func TestDispatcherRejectsBadSignature(t *testing.T) {
+	d := NewDispatcher("shhh")
+	d.HandleFunc("products/create", func(string, json.RawMessage) error { return nil })
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{}`))
+	req.Header.Set(HMACHeader, "bogus")
+	req.Header.Set("X-Shopify-Topic", "products/create")
+
+	rr := httptest.NewRecorder()
+	d.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestDispatcherUnknownTopic(t *testing.T) {
+	d := NewDispatcher("shhh")
+
+	req := signedRequest("shhh", `{}`)
+	req.Header.Set("X-Shopify-Topic", "orders/create")
+
+	rr := httptest.NewRecorder()
+	d.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotImplemented {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusNotImplemented)
+	}
+}
@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func signedRequest(secret, body string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(HMACHeader, sig)
+	return req
+}
+
+func TestVerifyRequestSuccess(t *testing.T) {
+	req := signedRequest("shhh", `{"id":1}`)
+
+	if err := VerifyRequest("shhh", req); err != nil {
+		t.Fatalf("VerifyRequest() = %v, want nil", err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("reading body after VerifyRequest: %v", err)
+	}
+	if string(body) != `{"id":1}` {
+		t.Fatalf("body after VerifyRequest = %q, want original payload still readable", body)
+	}
+}
+
+func TestVerifyRequestWrongSecret(t *testing.T) {
+	req := signedRequest("shhh", `{"id":1}`)
+
+	if err := VerifyRequest("different-secret", req); err != ErrInvalidHMAC {
+		t.Fatalf("VerifyRequest() = %v, want ErrInvalidHMAC", err)
+	}
+}
+
+func TestVerifyRequestTamperedBody(t *testing.T) {
+	req := signedRequest("shhh", `{"id":1}`)
+	req.Body = io.NopCloser(strings.NewReader(`{"id":2}`))
+
+	if err := VerifyRequest("shhh", req); err != ErrInvalidHMAC {
+		t.Fatalf("VerifyRequest() = %v, want ErrInvalidHMAC", err)
+	}
+}
+
+func TestVerifyRequestMissingHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{}`))
+
+	if err := VerifyRequest("shhh", req); err != ErrInvalidHMAC {
+		t.Fatalf("VerifyRequest() = %v, want ErrInvalidHMAC", err)
+	}
+}
+
+func TestVerifyRequestMalformedHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{}`))
+	req.Header.Set(HMACHeader, "not-valid-base64!!")
+
+	if err := VerifyRequest("shhh", req); err != ErrInvalidHMAC {
+		t.Fatalf("VerifyRequest() = %v, want ErrInvalidHMAC", err)
+	}
+}